@@ -0,0 +1,49 @@
+//go:build go1.23
+
+package log
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+)
+
+// CaptureCrashes redirects the runtime's fatal crash output into a sibling
+// file next to the logger's own output, so the crash record (including
+// panics in goroutines that never call recover, which Severe and Critical
+// cannot see) survives even if the main log file is later truncated or
+// rotated away. The file is prefixed with Options.BuildInfoString so
+// operators can correlate a crash to the build that produced it.
+//
+// CaptureCrashes requires Go 1.23 or newer; see runtime/debug.SetCrashOutput.
+func (l *Logger) CaptureCrashes() error {
+	f, err := os.OpenFile(l.crashFilePath(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(l.BuildInfoString() + "\n"); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if _, err := debug.SetCrashOutput(f, debug.CrashOptions{}); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return nil
+}
+
+// namedWriter is implemented by sinks that are backed by a single named
+// file on disk, such as closeableFile and RotatingFileLogger.
+type namedWriter interface {
+	Name() string
+}
+
+// crashFilePath returns the path crash output should be written to: a
+// ".crash" sibling of the logger's file if it is file-backed, or a file
+// named after the binary in the working directory otherwise.
+func (l *Logger) crashFilePath() string {
+	if named, ok := l.staticW.(namedWriter); ok {
+		return named.Name() + ".crash"
+	}
+	return fmt.Sprintf("%v.crash", l.staticOptions.BinaryName)
+}
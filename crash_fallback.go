@@ -0,0 +1,15 @@
+//go:build !go1.23
+
+package log
+
+import "errors"
+
+// errCrashCaptureUnsupported is returned by CaptureCrashes on Go versions
+// older than 1.23, which lack runtime/debug.SetCrashOutput.
+var errCrashCaptureUnsupported = errors.New("log: CaptureCrashes requires Go 1.23 or newer")
+
+// CaptureCrashes is a no-op on Go versions older than 1.23, which do not
+// provide runtime/debug.SetCrashOutput.
+func (l *Logger) CaptureCrashes() error {
+	return errCrashCaptureUnsupported
+}
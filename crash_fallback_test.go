@@ -0,0 +1,14 @@
+//go:build !go1.23
+
+package log
+
+import "testing"
+
+// TestCaptureCrashesUnsupported tests that CaptureCrashes reports its
+// sentinel error on Go versions older than 1.23.
+func TestCaptureCrashesUnsupported(t *testing.T) {
+	err := DiscardLogger.CaptureCrashes()
+	if err != errCrashCaptureUnsupported {
+		t.Errorf("expected errCrashCaptureUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCaptureCrashesWritesHeader tests that CaptureCrashes creates a
+// sibling crash file containing the build info header.
+func TestCaptureCrashesWritesHeader(t *testing.T) {
+	testdir := tempDir(t.Name())
+	if err := os.MkdirAll(testdir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	logFilename := filepath.Join(testdir, "test.log")
+
+	fl, err := NewFileLogger(logFilename, Options{BinaryName: "test", Version: "0.0.1", Release: Testing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fl.Close()
+
+	if err := fl.CaptureCrashes(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(logFilename + ".crash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "test v0.0.1") {
+		t.Errorf("expected the crash file to contain the build info header, got %q", data)
+	}
+}
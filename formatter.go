@@ -0,0 +1,94 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type (
+	// Record is a single structured log entry. It is passed to a Formatter
+	// to be rendered into bytes before being written to the Logger's
+	// underlying io.Writer.
+	Record struct {
+		// Time is when the record was created.
+		Time time.Time
+		// Level is the severity of the record.
+		Level Level
+		// Caller is the file:line of the call site that produced the
+		// record.
+		Caller string
+		// Message is the human-readable log message.
+		Message string
+		// Build is the result of Options.BuildInfoString, included so
+		// records can be correlated to a specific build.
+		Build string
+		// Fields contains the structured key/value pairs attached to the
+		// record, including any fields inherited from Logger.With.
+		Fields map[string]interface{}
+	}
+
+	// Formatter renders a Record into the bytes that get written to a
+	// Logger's underlying io.Writer. Implementations must return data that
+	// already ends in a newline.
+	Formatter interface {
+		Format(r Record) ([]byte, error)
+	}
+
+	// TextFormatter renders records using the human-readable format the
+	// package has historically used: a timestamp, the level, the caller,
+	// the message, and any fields appended as "key=value" pairs.
+	TextFormatter struct{}
+
+	// JSONFormatter renders records as a single JSON object per line,
+	// suitable for machine-parseable log pipelines.
+	JSONFormatter struct{}
+)
+
+// textTimeFormat matches the timestamp produced by the standard library
+// logger flags used elsewhere in this package (log.Ldate|log.Ltime|log.Lmicroseconds|log.LUTC).
+const textTimeFormat = "2006/01/02 15:04:05.000000"
+
+// Format renders r in the package's traditional human-readable format.
+func (TextFormatter) Format(r Record) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%v %v %v: %v", r.Time.UTC().Format(textTimeFormat), r.Level, r.Caller, r.Message)
+	for _, k := range sortedKeys(r.Fields) {
+		fmt.Fprintf(&buf, " %v=%v", k, r.Fields[k])
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// Format renders r as a single JSON object with "ts", "level", "caller",
+// "msg", and "build" keys, merged with the record's fields.
+func (JSONFormatter) Format(r Record) ([]byte, error) {
+	m := make(map[string]interface{}, len(r.Fields)+5)
+	for k, v := range r.Fields {
+		m[k] = v
+	}
+	m["ts"] = r.Time.UTC().Format(time.RFC3339Nano)
+	m["level"] = r.Level.String()
+	m["caller"] = r.Caller
+	m["msg"] = r.Message
+	m["build"] = r.Build
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// sortedKeys returns the keys of m in sorted order, so that text-formatted
+// fields are deterministic.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,47 @@
+package log
+
+// Level identifies the severity of a log record. Levels are ordered from
+// least to most severe, and are used both to filter which records are
+// emitted and to annotate structured log output.
+type Level int32
+
+const (
+	// LevelDebug is used for verbose diagnostic output that is only useful
+	// while developing or debugging.
+	LevelDebug Level = iota
+	// LevelInfo is used for routine operational messages.
+	LevelInfo
+	// LevelWarn is used for messages that indicate a potential problem but
+	// do not require immediate attention.
+	LevelWarn
+	// LevelError is used for messages that indicate something has gone
+	// wrong.
+	LevelError
+	// LevelSevere is used for messages that indicate a severe problem with
+	// the user's machine or setup, mirroring Logger.Severe.
+	LevelSevere
+	// LevelCritical is used for messages that indicate developer error,
+	// mirroring Logger.Critical.
+	LevelCritical
+)
+
+// String returns a short uppercase name for the level, suitable for use in
+// log output.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelSevere:
+		return "SEVERE"
+	case LevelCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
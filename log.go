@@ -8,6 +8,7 @@ import (
 	"os"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 )
 
 type (
@@ -18,6 +19,17 @@ type (
 		*log.Logger
 		staticW       io.Writer
 		staticOptions Options
+		staticFields  map[string]interface{}
+		// writeMu guards every write to staticW, whether it comes from the
+		// embedded *log.Logger's Output (via the syncWriter passed to
+		// log.New) or from logw's direct write of a formatted Record. It is
+		// a pointer so that With can share it with child loggers, which
+		// write to the same staticW.
+		writeMu *sync.Mutex
+		level   int32 // atomic, access via Level/SetLevel
+
+		sampleMu    sync.Mutex
+		sampleState map[sampleKey]*sampleWindow
 	}
 
 	// Options contains logger options. It is required to instantiate the
@@ -30,6 +42,16 @@ type (
 		// Debug enables debug logging and will cause the logger to panic when
 		// calling Critical or Severe.
 		Debug bool
+		// Formatter controls how the structured logging methods (Infow,
+		// Errorw, etc.) render a Record before writing it. Defaults to
+		// TextFormatter if left nil.
+		Formatter Formatter
+		// Level is the Logger's initial minimum level; records and
+		// messages below it are dropped. If Level is left at its zero
+		// value (LevelDebug) and Debug is false, it defaults to LevelInfo
+		// instead, preserving the historical meaning of Options.Debug. The
+		// level can be changed at runtime with Logger.SetLevel.
+		Level Level
 		// Release is the release mode.
 		Release ReleaseType
 		// Version is the binary version.
@@ -117,42 +139,72 @@ func (l *Logger) Close() error {
 // os.Stderr and panic. Critical should only be called if there has been a
 // developer error, otherwise Severe should be called.
 func (l *Logger) Critical(v ...interface{}) {
-	_ = l.Output(2, "CRITICAL: "+fmt.Sprintln(v...))
+	if l.isEnabled(LevelCritical) {
+		_ = l.Output(2, "CRITICAL: "+fmt.Sprintln(v...))
+	}
 	l.staticOptions.Critical(v...)
 }
 
-// Debug is equivalent to Logger.Print when build.DEBUG is true. Otherwise it
-// is a no-op.
+// Debug is equivalent to Logger.Print when the Logger's level is LevelDebug
+// or lower. Otherwise it is a no-op.
 func (l *Logger) Debug(v ...interface{}) {
-	if l.staticOptions.Debug {
+	if l.isEnabled(LevelDebug) {
 		_ = l.Output(2, fmt.Sprint(v...))
 	}
 }
 
-// Debugf is equivalent to Logger.Printf when build.DEBUG is true. Otherwise it
-// is a no-op.
+// Debugf is equivalent to Logger.Printf when the Logger's level is
+// LevelDebug or lower. Otherwise it is a no-op.
 func (l *Logger) Debugf(format string, v ...interface{}) {
-	if l.staticOptions.Debug {
+	if l.isEnabled(LevelDebug) {
 		_ = l.Output(2, fmt.Sprintf(format, v...))
 	}
 }
 
-// Debugln is equivalent to Logger.Println when build.DEBUG is true. Otherwise
-// it is a no-op.
+// Debugln is equivalent to Logger.Println when the Logger's level is
+// LevelDebug or lower. Otherwise it is a no-op.
 func (l *Logger) Debugln(v ...interface{}) {
-	if l.staticOptions.Debug {
+	if l.isEnabled(LevelDebug) {
 		_ = l.Output(2, "[DEBUG] "+fmt.Sprintln(v...))
 	}
 }
 
+// Println is equivalent to the embedded log.Logger's Println, but is
+// dropped if the Logger's level is above LevelInfo.
+func (l *Logger) Println(v ...interface{}) {
+	if l.isEnabled(LevelInfo) {
+		_ = l.Output(2, fmt.Sprintln(v...))
+	}
+}
+
+// Printf is equivalent to the embedded log.Logger's Printf, but is dropped
+// if the Logger's level is above LevelInfo.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	if l.isEnabled(LevelInfo) {
+		_ = l.Output(2, fmt.Sprintf(format, v...))
+	}
+}
+
+// Print is equivalent to the embedded log.Logger's Print, but is dropped if
+// the Logger's level is above LevelInfo.
+func (l *Logger) Print(v ...interface{}) {
+	if l.isEnabled(LevelInfo) {
+		_ = l.Output(2, fmt.Sprint(v...))
+	}
+}
+
 // Errorf is equivalent to Logger.Printf with '[ERROR] ' prefix.
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	_ = l.Output(2, "[ERROR] "+fmt.Sprintf(format, v...))
+	if l.isEnabled(LevelError) {
+		_ = l.Output(2, "[ERROR] "+fmt.Sprintf(format, v...))
+	}
 }
 
 // Errorln is equivalent to Logger.Println with '[ERROR] ' prefix.
 func (l *Logger) Errorln(v ...interface{}) {
-	_ = l.Output(2, "[ERROR] "+fmt.Sprintln(v...))
+	if l.isEnabled(LevelError) {
+		_ = l.Output(2, "[ERROR] "+fmt.Sprintln(v...))
+	}
 }
 
 // Severe logs a message with a SEVERE prefix. If debug mode is enabled, it
@@ -161,7 +213,9 @@ func (l *Logger) Errorln(v ...interface{}) {
 // addressed ASAP but does not necessarily require that the machine crash or
 // exit.
 func (l *Logger) Severe(v ...interface{}) {
-	_ = l.Output(2, "SEVERE: "+fmt.Sprintln(v...))
+	if l.isEnabled(LevelSevere) {
+		_ = l.Output(2, "SEVERE: "+fmt.Sprintln(v...))
+	}
 	s := fmt.Sprintf("Severe error: %v %v", l.BuildInfoString(), fmt.Sprintln(v...))
 	if l.staticOptions.Release != Testing {
 		debug.PrintStack()
@@ -172,6 +226,26 @@ func (l *Logger) Severe(v ...interface{}) {
 	}
 }
 
+// Level returns the Logger's current minimum level. It is safe to call
+// concurrently with SetLevel and any logging method.
+func (l *Logger) Level() Level {
+	return Level(atomic.LoadInt32(&l.level))
+}
+
+// SetLevel changes the Logger's minimum level at runtime. Records and
+// messages below the new level are dropped by subsequent calls. It is safe
+// to call concurrently with any logging method, e.g. from a SIGUSR1 handler
+// or an admin HTTP endpoint that exposes runtime level control.
+func (l *Logger) SetLevel(level Level) {
+	atomic.StoreInt32(&l.level, int32(level))
+}
+
+// isEnabled reports whether a message at level should be emitted given the
+// Logger's current minimum level.
+func (l *Logger) isEnabled(level Level) bool {
+	return level >= l.Level()
+}
+
 // NewLogger returns a logger that can be closed. Calls should not be made to
 // the logger after 'Close' has been called.
 func NewLogger(w io.Writer, options Options) (*Logger, error) {
@@ -181,12 +255,38 @@ func NewLogger(w io.Writer, options Options) (*Logger, error) {
 		return nil, fmt.Errorf("invalid ReleaseType provided: %v", options.Release.String())
 	}
 	message := fmt.Sprintf("STARTUP: Logging has started. %v Version %v", options.BinaryName, options.Version)
-	l := log.New(w, "", log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile|log.LUTC)
+	if options.Formatter == nil {
+		options.Formatter = TextFormatter{}
+	}
+	level := options.Level
+	if level == LevelDebug && !options.Debug {
+		level = LevelInfo
+	}
+	writeMu := new(sync.Mutex)
+	l := log.New(&syncWriter{mu: writeMu, w: w}, "", log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile|log.LUTC)
 	err := l.Output(3, message) // Call depth is 3 because NewLogger is usually called by NewFileLogger
 	if err != nil {
 		return nil, err
 	}
-	return &Logger{l, w, options}, nil
+	return &Logger{Logger: l, staticW: w, staticOptions: options, writeMu: writeMu, level: int32(level)}, nil
+}
+
+// syncWriter serializes writes to w behind mu. It is the sole writer passed
+// to the embedded *log.Logger, so that writes made through Output (the
+// classic Println/Errorf/Debugf/... methods) and writes made directly to
+// staticW (the structured Infow/Errorw/... methods, via logw) contend for
+// the same lock instead of two independent ones guarding the same
+// underlying io.Writer.
+type syncWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+// Write locks mu and writes p to the wrapped writer.
+func (sw *syncWriter) Write(p []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.w.Write(p)
 }
 
 // closeableFile wraps an os.File to perform sanity checks on its Write and
@@ -249,8 +349,9 @@ func newDiscardLogger() *Logger {
 		Debug: true,
 		// Set the release type to avoid "uninitialized release type" panic. The
 		// discard logger is mostly used for testing.
-		Release: Testing,
-		Version: "0",
+		Release:   Testing,
+		Version:   "0",
+		Formatter: TextFormatter{},
 	}
-	return &Logger{l, w, options}
+	return &Logger{Logger: l, staticW: w, staticOptions: options, writeMu: new(sync.Mutex), level: int32(LevelDebug)}
 }
@@ -286,3 +286,35 @@ func testLogContainsMessage(t *testing.T, logFilepath, message string) {
 	}
 	t.Error("did not find the expected message in the logger")
 }
+
+// TestLoggerSetLevel tests that SetLevel changes which messages Println and
+// Debug are willing to emit at runtime.
+func TestLoggerSetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := NewLogger(&buf, Options{Release: Testing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Level() != LevelInfo {
+		t.Fatalf("expected the default level to be LevelInfo, got %v", l.Level())
+	}
+	buf.Reset()
+
+	l.Debug("should be dropped, below LevelInfo")
+	if buf.Len() != 0 {
+		t.Fatalf("expected Debug to be dropped, got %q", buf.String())
+	}
+
+	l.SetLevel(LevelDebug)
+	l.Debug("should be kept")
+	if !strings.Contains(buf.String(), "should be kept") {
+		t.Fatalf("expected Debug to be kept after lowering the level, got %q", buf.String())
+	}
+
+	buf.Reset()
+	l.SetLevel(LevelError)
+	l.Println("should be dropped, below LevelError")
+	if buf.Len() != 0 {
+		t.Fatalf("expected Println to be dropped above its level, got %q", buf.String())
+	}
+}
@@ -0,0 +1,62 @@
+package log
+
+import "io"
+
+// multiWriter fans writes out to a fixed set of sinks. Unlike io.MultiWriter,
+// a write error from one sink does not prevent the write from reaching the
+// remaining sinks, so a single bad sink (e.g. a syslog daemon that is
+// temporarily unreachable) cannot break logging to the others.
+type multiWriter struct {
+	sinks []io.Writer
+}
+
+// Write writes b to every sink, continuing past individual sink errors. It
+// returns the first error encountered, if any, but always reports len(b) as
+// written so that callers relying on the standard library logger do not
+// treat a single failing sink as a fatal logging error.
+func (mw *multiWriter) Write(b []byte) (int, error) {
+	var firstErr error
+	for _, sink := range mw.sinks {
+		if _, err := sink.Write(b); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return len(b), firstErr
+}
+
+// WriteLevel fans b out to every sink at level, using each sink's own
+// WriteLevel if it implements levelWriter and falling back to Write
+// otherwise, so a sink such as syslogWriter still sees the record's real
+// Level instead of having to recover it from b's rendered text. Error
+// isolation mirrors Write: a failing sink does not prevent the write from
+// reaching the others.
+func (mw *multiWriter) WriteLevel(level Level, b []byte) (int, error) {
+	var firstErr error
+	for _, sink := range mw.sinks {
+		if _, err := writeRecord(sink, level, b); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return len(b), firstErr
+}
+
+// Close closes every sink that implements io.Closer, continuing past
+// individual sink errors and returning the first one encountered, if any.
+func (mw *multiWriter) Close() error {
+	var firstErr error
+	for _, sink := range mw.sinks {
+		if c, ok := sink.(io.Closer); ok {
+			if err := c.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// NewMultiLogger returns a logger that fans every write out to sinks, e.g. a
+// file, os.Stderr, and a syslog writer simultaneously. A sink that errors on
+// a given write does not prevent the write from reaching the other sinks.
+func NewMultiLogger(opts Options, sinks ...io.Writer) (*Logger, error) {
+	return NewLogger(&multiWriter{sinks: sinks}, opts)
+}
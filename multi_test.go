@@ -0,0 +1,50 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// erroringWriter always fails its Write calls, to simulate a broken sink.
+type erroringWriter struct{}
+
+func (erroringWriter) Write(b []byte) (int, error) {
+	return 0, errors.New("sink is broken")
+}
+
+// TestMultiWriterIsolation tests that a failing sink does not prevent the
+// write from reaching the other sinks.
+func TestMultiWriterIsolation(t *testing.T) {
+	var good bytes.Buffer
+	mw := &multiWriter{sinks: []io.Writer{erroringWriter{}, &good}}
+
+	n, err := mw.Write([]byte("hello\n"))
+	if n != len("hello\n") {
+		t.Errorf("expected n to report the full length written, got %v", n)
+	}
+	if err == nil {
+		t.Error("expected the broken sink's error to be surfaced")
+	}
+	if !strings.Contains(good.String(), "hello") {
+		t.Errorf("expected the healthy sink to still receive the write, got %q", good.String())
+	}
+}
+
+// TestNewMultiLogger tests that a MultiLogger fans a logged line out to
+// every sink.
+func TestNewMultiLogger(t *testing.T) {
+	var a, b bytes.Buffer
+	l, err := NewMultiLogger(Options{Release: Testing}, &a, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Println("TEST: fan out")
+	for _, buf := range []*bytes.Buffer{&a, &b} {
+		if !strings.Contains(buf.String(), "TEST: fan out") {
+			t.Errorf("expected sink to contain the log line, got %q", buf.String())
+		}
+	}
+}
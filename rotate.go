@@ -0,0 +1,321 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// rotateCheckInterval is how often a RotatingFileLogger checks whether its
+// file has been renamed out from under it by an external tool such as
+// logrotate.
+const rotateCheckInterval = 10 * time.Second
+
+// RotationOptions configures when and how a RotatingFileLogger rotates its
+// log file.
+type RotationOptions struct {
+	// MaxSizeBytes is the size at which the log file is rotated. Zero
+	// disables size-based rotation.
+	MaxSizeBytes int64
+	// MaxAge is how long a rotated backup is kept before being pruned.
+	// Zero disables age-based pruning.
+	MaxAge time.Duration
+	// MaxBackups is the number of most recent rotated backups to keep;
+	// older ones are pruned. Zero keeps every backup.
+	MaxBackups int
+	// Compress gzips backups in the background after rotation.
+	Compress bool
+	// RotateAtMidnight rotates the file once per UTC day, in addition to
+	// any size-based rotation.
+	RotateAtMidnight bool
+}
+
+// RotatingFileLogger is an io.WriteCloser that wraps a closeableFile and
+// rotates it when RotationOptions thresholds are exceeded. It is safe for
+// concurrent use.
+type RotatingFileLogger struct {
+	staticFilename string
+	staticOptions  Options
+	staticRotation RotationOptions
+
+	mu          sync.RWMutex
+	file        *closeableFile
+	size        int64
+	openDay     string
+	done        chan struct{}
+	compressing map[string]struct{} // backups gzipFile currently holds open
+}
+
+// NewRotatingFileLogger returns a logger that logs to logFilename, rotating
+// it according to rotation. The file is opened in append mode and created
+// if it does not exist, matching NewFileLogger.
+func NewRotatingFileLogger(logFilename string, rotation RotationOptions, options Options) (*Logger, error) {
+	r, err := newRotatingFileLogger(logFilename, rotation, options)
+	if err != nil {
+		return nil, err
+	}
+	return NewLogger(r, options)
+}
+
+// newRotatingFileLogger opens logFilename and starts the background watcher
+// that detects external renames.
+func newRotatingFileLogger(logFilename string, rotation RotationOptions, options Options) (*RotatingFileLogger, error) {
+	r := &RotatingFileLogger{
+		staticFilename: logFilename,
+		staticOptions:  options,
+		staticRotation: rotation,
+		done:           make(chan struct{}),
+	}
+	if err := r.openLocked(); err != nil {
+		return nil, err
+	}
+	go r.watchExternalRotation()
+	return r, nil
+}
+
+// openLocked opens (or reopens) the base log file and records its current
+// size and day, for callers already holding r.mu.
+func (r *RotatingFileLogger) openLocked() error {
+	f, err := os.OpenFile(r.staticFilename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0660)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.file = &closeableFile{File: f, staticOptions: r.staticOptions}
+	r.size = info.Size()
+	r.openDay = time.Now().UTC().Format("20060102")
+	return nil
+}
+
+// Write writes b to the log file, rotating first if b would push the file
+// past the configured thresholds.
+func (r *RotatingFileLogger) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shouldRotateLocked(len(b)) {
+		if err := r.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(b)
+	r.size += int64(n)
+	return n, err
+}
+
+// Name returns the base filename being logged to, ignoring any rotated
+// backups.
+func (r *RotatingFileLogger) Name() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.staticFilename
+}
+
+// Close stops the background watcher and closes the underlying file.
+func (r *RotatingFileLogger) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	close(r.done)
+	return r.file.Close()
+}
+
+// shouldRotateLocked reports whether writing n more bytes should trigger a
+// rotation, for a caller already holding r.mu.
+func (r *RotatingFileLogger) shouldRotateLocked(n int) bool {
+	if r.staticRotation.MaxSizeBytes > 0 && r.size+int64(n) > r.staticRotation.MaxSizeBytes {
+		return true
+	}
+	if r.staticRotation.RotateAtMidnight && time.Now().UTC().Format("20060102") != r.openDay {
+		return true
+	}
+	return false
+}
+
+// rotateLocked closes the current file, renames it aside, reopens the base
+// filename, and prunes old backups. The caller must hold r.mu.
+func (r *RotatingFileLogger) rotateLocked() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	backup := r.backupPath()
+	if err := os.Rename(r.staticFilename, backup); err != nil {
+		return err
+	}
+
+	if r.staticRotation.Compress {
+		if r.compressing == nil {
+			r.compressing = make(map[string]struct{})
+		}
+		r.compressing[backup] = struct{}{}
+		go r.compressBackup(backup)
+	}
+	go r.pruneBackups()
+
+	return r.openLocked()
+}
+
+// backupPath returns a unique name for the file being rotated out,
+// disambiguating with a numeric suffix if a backup with the same timestamp
+// already exists.
+func (r *RotatingFileLogger) backupPath() string {
+	stamp := time.Now().UTC().Format("20060102-150405")
+	base := fmt.Sprintf("%v.%v", r.staticFilename, stamp)
+	path := base
+	for i := 1; fileExists(path); i++ {
+		path = fmt.Sprintf("%v.%v", base, i)
+	}
+	return path
+}
+
+// backupGlobSuffix matches the "YYYYMMDD-HHMMSS" stamp backupPath appends
+// to the base filename, plus anything after it (a numeric disambiguator,
+// a ".gz" from compression, or both). It deliberately excludes other
+// filename-prefixed siblings such as the ".crash" file CaptureCrashes
+// writes next to the same base name, so pruneBackups only ever touches
+// backups it created.
+const backupGlobSuffix = ".[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]-[0-9][0-9][0-9][0-9][0-9][0-9]*"
+
+// pruneBackups removes rotated backups beyond MaxBackups and older than
+// MaxAge. It runs in its own goroutine so that rotation is not held up by
+// disk I/O on old files.
+//
+// A backup still being gzipped by compressBackup is skipped regardless of
+// its position or age: rotations can outpace compression under sustained
+// write volume, and removing a backup mid-compress would delete the only
+// surviving copy of that generation (neither the plain file nor a .gz
+// would be left). It is reconsidered on the next rotation's prune, once
+// compressBackup has finished with it.
+func (r *RotatingFileLogger) pruneBackups() {
+	matches, err := filepath.Glob(r.staticFilename + backupGlobSuffix)
+	if err != nil {
+		r.staticOptions.Critical("failed to glob log backups:", err)
+		return
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+
+	r.mu.RLock()
+	busy := make(map[string]struct{}, len(r.compressing))
+	for path := range r.compressing {
+		busy[path] = struct{}{}
+	}
+	r.mu.RUnlock()
+
+	now := time.Now()
+	for i, path := range matches {
+		if _, ok := busy[path]; ok {
+			continue
+		}
+		keepByCount := r.staticRotation.MaxBackups <= 0 || i < r.staticRotation.MaxBackups
+		expired := false
+		if r.staticRotation.MaxAge > 0 {
+			if info, err := os.Stat(path); err == nil {
+				expired = now.Sub(info.ModTime()) > r.staticRotation.MaxAge
+			}
+		}
+		if !keepByCount || expired {
+			_ = os.Remove(path)
+		}
+	}
+}
+
+// watchExternalRotation periodically checks whether staticFilename still
+// refers to the file this logger has open, reopening it if an external tool
+// like logrotate has renamed it out from under the logger.
+func (r *RotatingFileLogger) watchExternalRotation() {
+	ticker := time.NewTicker(rotateCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			r.reopenIfRenamed()
+		}
+	}
+}
+
+// reopenIfRenamed reopens the base filename if it no longer refers to the
+// file currently held open.
+func (r *RotatingFileLogger) reopenIfRenamed() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.staticFilename)
+	if err != nil || !os.SameFile(info, statOrNil(r.file.File)) {
+		if err := r.file.Close(); err != nil {
+			r.staticOptions.Critical("failed to close rotated-away log file:", err)
+		}
+		if err := r.openLocked(); err != nil {
+			r.staticOptions.Critical("failed to reopen log file after external rotation:", err)
+		}
+	}
+}
+
+// statOrNil stats f, returning nil if it fails (e.g. f was already closed).
+func statOrNil(f *os.File) os.FileInfo {
+	info, err := f.Stat()
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// compressBackup gzips path, then clears it from r.compressing so a later
+// pruneBackups call is free to remove it. It runs in its own goroutine,
+// spawned from rotateLocked, so rotation is not held up by gzip's disk
+// I/O.
+func (r *RotatingFileLogger) compressBackup(path string) {
+	gzipFile(path)
+	r.mu.Lock()
+	delete(r.compressing, path)
+	r.mu.Unlock()
+}
+
+// gzipFile gzips path in place, removing the uncompressed file once the
+// compressed copy has been written successfully.
+func gzipFile(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dstPath := path + ".gz"
+	dst, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		_ = gz.Close()
+		_ = dst.Close()
+		_ = os.Remove(dstPath)
+		return
+	}
+	if err := gz.Close(); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(dstPath)
+		return
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(dstPath)
+		return
+	}
+	_ = os.Remove(path)
+}
@@ -0,0 +1,53 @@
+package log
+
+import (
+	"runtime"
+	"time"
+)
+
+// sampleKey identifies the call site a Sampled call was made from.
+type sampleKey struct {
+	file string
+	line int
+}
+
+// sampleWindow tracks how many times a call site has fired within the
+// current sampling window.
+type sampleWindow struct {
+	start time.Time
+	count int
+}
+
+// Sampled reports whether the caller's call site is still within its
+// sampling allowance: the first n calls from a given file:line within each
+// window of length per return true, and the rest return false, so a hot
+// loop logging the same error on every iteration cannot flood disk. Each
+// call site is tracked independently, keyed by the file and line Sampled
+// was called from, and a new window starts the first time a call site is
+// seen again after its previous window has elapsed. Sampled is typically
+// used to guard a single log call:
+//
+//	if logger.Sampled(5, time.Minute) {
+//		logger.Errorf("connection reset: %v", err)
+//	}
+func (l *Logger) Sampled(n int, per time.Duration) bool {
+	_, file, line, ok := runtime.Caller(1)
+	if !ok {
+		return true
+	}
+	key := sampleKey{file: file, line: line}
+
+	l.sampleMu.Lock()
+	defer l.sampleMu.Unlock()
+	if l.sampleState == nil {
+		l.sampleState = make(map[sampleKey]*sampleWindow)
+	}
+	now := time.Now()
+	w, ok := l.sampleState[key]
+	if !ok || now.Sub(w.start) >= per {
+		w = &sampleWindow{start: now}
+		l.sampleState[key] = w
+	}
+	w.count++
+	return w.count <= n
+}
@@ -0,0 +1,57 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSampledDropsAfterN tests that Sampled allows the first n calls from a
+// call site through and drops the rest within the same window.
+func TestSampledDropsAfterN(t *testing.T) {
+	l, _ := newBufLogger(t, Options{})
+
+	var allowed int
+	for i := 0; i < 10; i++ {
+		if l.Sampled(3, time.Minute) {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Fatalf("expected 3 calls to be allowed, got %v", allowed)
+	}
+}
+
+// TestSampledResetsAfterWindow tests that a call site's allowance refreshes
+// once its window has elapsed.
+func TestSampledResetsAfterWindow(t *testing.T) {
+	l, _ := newBufLogger(t, Options{})
+	sample := func() bool { return l.Sampled(1, 50*time.Millisecond) }
+
+	if !sample() {
+		t.Fatal("expected the first call to be allowed")
+	}
+	if sample() {
+		t.Fatal("expected the second call within the window to be dropped")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if !sample() {
+		t.Fatal("expected the call after the window elapsed to be allowed")
+	}
+}
+
+// TestSampledPerCallSite tests that two distinct call sites are tracked
+// independently.
+func TestSampledPerCallSite(t *testing.T) {
+	l, _ := newBufLogger(t, Options{})
+
+	sampleA := func() bool { return l.Sampled(1, time.Minute) }
+	sampleB := func() bool { return l.Sampled(1, time.Minute) }
+
+	if !sampleA() || !sampleB() {
+		t.Fatal("expected the first call from each distinct call site to be allowed")
+	}
+	if sampleA() || sampleB() {
+		t.Fatal("expected the second call from each call site to be dropped")
+	}
+}
@@ -0,0 +1,141 @@
+package log
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// socketBufferedMessages is the number of log lines socketWriter will
+	// buffer while disconnected before it starts dropping them.
+	socketBufferedMessages = 256
+	// socketDialTimeout bounds how long a single reconnect attempt may
+	// take before socketWriter gives up and retries later.
+	socketDialTimeout = 5 * time.Second
+	// socketRetryInterval is how long socketWriter waits after a failed
+	// dial before trying again.
+	socketRetryInterval = time.Second
+)
+
+// errSocketBufferFull is returned by socketWriter.Write when the sink is
+// disconnected and its bounded buffer of pending messages is full.
+var errSocketBufferFull = errors.New("log: socket sink buffer is full, message dropped")
+
+// socketWriter is an io.Writer that streams log lines to a TCP, UDP, or unix
+// socket. Writes never block on the network: lines are queued to a bounded
+// buffer and delivered by a background goroutine that transparently
+// reconnects if the connection is lost.
+type socketWriter struct {
+	staticNetwork string
+	staticAddr    string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	queue  chan []byte
+	done   chan struct{}
+	closed bool
+}
+
+// newSocketWriter starts a socketWriter that dials network/addr in the
+// background and reconnects automatically if the connection drops.
+func newSocketWriter(network, addr string) *socketWriter {
+	sw := &socketWriter{
+		staticNetwork: network,
+		staticAddr:    addr,
+		queue:         make(chan []byte, socketBufferedMessages),
+		done:          make(chan struct{}),
+	}
+	go sw.deliver()
+	return sw
+}
+
+// Write queues b for delivery, copying it since the caller may reuse its
+// buffer. If the queue is full, the message is dropped and
+// errSocketBufferFull is returned so that callers can detect the loss.
+func (sw *socketWriter) Write(b []byte) (int, error) {
+	cp := make([]byte, len(b))
+	copy(cp, b)
+	select {
+	case sw.queue <- cp:
+		return len(b), nil
+	default:
+		return 0, errSocketBufferFull
+	}
+}
+
+// Close stops the delivery goroutine and closes the active connection, if
+// any.
+func (sw *socketWriter) Close() error {
+	sw.mu.Lock()
+	if sw.closed {
+		sw.mu.Unlock()
+		return nil
+	}
+	sw.closed = true
+	conn := sw.conn
+	sw.mu.Unlock()
+
+	close(sw.done)
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+// deliver drains the queue and writes each message to the socket,
+// reconnecting whenever the connection is missing or broken.
+func (sw *socketWriter) deliver() {
+	for {
+		select {
+		case <-sw.done:
+			return
+		case b := <-sw.queue:
+			sw.writeWithReconnect(b)
+		}
+	}
+}
+
+// writeWithReconnect writes b to the connection, dialing a new one first if
+// necessary. A write failure drops the connection so the next message
+// triggers a fresh dial.
+func (sw *socketWriter) writeWithReconnect(b []byte) {
+	sw.mu.Lock()
+	conn := sw.conn
+	sw.mu.Unlock()
+
+	for conn == nil {
+		var err error
+		conn, err = net.DialTimeout(sw.staticNetwork, sw.staticAddr, socketDialTimeout)
+		if err != nil {
+			select {
+			case <-sw.done:
+				return
+			case <-time.After(socketRetryInterval):
+			}
+			continue
+		}
+		sw.mu.Lock()
+		sw.conn = conn
+		sw.mu.Unlock()
+	}
+
+	if _, err := conn.Write(b); err != nil {
+		sw.mu.Lock()
+		if sw.conn == conn {
+			sw.conn = nil
+		}
+		sw.mu.Unlock()
+		_ = conn.Close()
+	}
+}
+
+// NewSocketLogger returns a logger that streams to a TCP, UDP, or unix
+// socket at addr (network is e.g. "tcp", "udp", or "unix"). The connection
+// is dialed lazily and re-established automatically if it drops; messages
+// written while disconnected are queued in a bounded buffer and dropped if
+// it fills up, so a slow or unreachable collector cannot block the caller.
+func NewSocketLogger(network, addr string, opts Options) (*Logger, error) {
+	return NewLogger(newSocketWriter(network, addr), opts)
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSocketLoggerDelivers tests that a socket logger delivers lines to a
+// listening TCP server.
+func TestSocketLoggerDelivers(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan string, 8)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			received <- scanner.Text()
+		}
+	}()
+
+	l, err := NewSocketLogger("tcp", ln.Addr().String(), Options{Release: Testing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.Println("TEST: socket delivery")
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case line := <-received:
+			if strings.Contains(line, "TEST: socket delivery") {
+				return
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for the socket sink to receive the expected message")
+		}
+	}
+}
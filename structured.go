@@ -0,0 +1,146 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// levelWriter is implemented by io.Writer sinks that can route a write by
+// its Level directly, such as syslogWriter. A structured record's rendered
+// bytes depend on the Logger's configured Formatter, and that rendering
+// can't always be parsed back into a severity (TextFormatter and
+// JSONFormatter place the level in different spots, or not recoverably at
+// all), so logw prefers WriteLevel over Write whenever a sink supports it.
+type levelWriter interface {
+	io.Writer
+	WriteLevel(level Level, p []byte) (int, error)
+}
+
+// writeRecord writes data — the Formatter's rendering of a record at
+// level — to w, using w's WriteLevel if it implements levelWriter and
+// falling back to a plain Write otherwise.
+func writeRecord(w io.Writer, level Level, data []byte) (int, error) {
+	if lw, ok := w.(levelWriter); ok {
+		return lw.WriteLevel(level, data)
+	}
+	return w.Write(data)
+}
+
+// With returns a child Logger that writes through to the same destination
+// and settings as l, but with kv merged into every structured record it
+// emits via Infow, Errorw, Debugw, Severew, and Criticalw. kv is
+// interpreted as alternating keys and values, e.g.
+//
+//	log := parent.With("binary", "uplod", "version", "1.6.0")
+func (l *Logger) With(kv ...interface{}) *Logger {
+	fields := make(map[string]interface{}, len(l.staticFields)+len(kv)/2)
+	for k, v := range l.staticFields {
+		fields[k] = v
+	}
+	mergeFields(fields, kv)
+	return &Logger{
+		Logger:        l.Logger,
+		staticW:       l.staticW,
+		staticOptions: l.staticOptions,
+		staticFields:  fields,
+		// Share the parent's write-lock rather than allocating a new one:
+		// l and the returned child write to the same staticW, so they must
+		// contend for the same mutex or their writes can interleave.
+		writeMu: l.writeMu,
+		level:   int32(l.Level()),
+	}
+}
+
+// Debugw logs a structured message at LevelDebug.
+func (l *Logger) Debugw(msg string, kv ...interface{}) {
+	l.logw(LevelDebug, msg, kv)
+}
+
+// Infow logs a structured message at LevelInfo.
+func (l *Logger) Infow(msg string, kv ...interface{}) {
+	l.logw(LevelInfo, msg, kv)
+}
+
+// Errorw logs a structured message at LevelError.
+func (l *Logger) Errorw(msg string, kv ...interface{}) {
+	l.logw(LevelError, msg, kv)
+}
+
+// Severew logs a structured message at LevelSevere. Like Severe, it prints
+// a stack trace outside of Testing mode and panics if Debug is enabled.
+func (l *Logger) Severew(msg string, kv ...interface{}) {
+	l.logw(LevelSevere, msg, kv)
+	s := fmt.Sprintf("Severe error: %v %v", l.BuildInfoString(), msg)
+	if l.staticOptions.Release != Testing {
+		debug.PrintStack()
+		_, _ = os.Stderr.WriteString(s)
+	}
+	if l.staticOptions.Debug {
+		panic(s)
+	}
+}
+
+// Criticalw logs a structured message at LevelCritical. Like Critical, it
+// guides the caller to the issue tracker and panics if Debug is enabled.
+func (l *Logger) Criticalw(msg string, kv ...interface{}) {
+	l.logw(LevelCritical, msg, kv)
+	l.staticOptions.Critical(msg)
+}
+
+// logw builds a Record for msg and kv, and writes it through the
+// configured Formatter if its level meets the Logger's minimum level.
+func (l *Logger) logw(level Level, msg string, kv []interface{}) {
+	if !l.isEnabled(level) {
+		return
+	}
+	fields := make(map[string]interface{}, len(l.staticFields)+len(kv)/2)
+	for k, v := range l.staticFields {
+		fields[k] = v
+	}
+	mergeFields(fields, kv)
+
+	_, file, line, ok := runtime.Caller(2)
+	caller := "???"
+	if ok {
+		caller = fmt.Sprintf("%v:%v", file, line)
+	}
+
+	r := Record{
+		Time:    time.Now(),
+		Level:   level,
+		Caller:  caller,
+		Message: msg,
+		Build:   l.BuildInfoString(),
+		Fields:  fields,
+	}
+	data, err := l.staticOptions.Formatter.Format(r)
+	if err != nil {
+		_ = l.Output(2, "[ERROR] failed to format log record: "+err.Error())
+		return
+	}
+	l.writeMu.Lock()
+	_, _ = writeRecord(l.staticW, level, data)
+	l.writeMu.Unlock()
+}
+
+// mergeFields interprets kv as alternating keys and values and merges them
+// into fields. A key that isn't a string is recorded under a synthetic
+// "!BADKEY<index>" key, and a trailing key with no value is recorded with the
+// value "!MISSING", rather than either being silently dropped.
+func mergeFields(fields map[string]interface{}, kv []interface{}) {
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprintf("!BADKEY%d", i)
+		}
+		if i+1 >= len(kv) {
+			fields[key] = "!MISSING"
+			break
+		}
+		fields[key] = kv[i+1]
+	}
+}
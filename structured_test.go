@@ -0,0 +1,188 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newBufLogger returns a Logger writing to an in-memory buffer, for tests
+// that inspect the structured logging output directly.
+func newBufLogger(t *testing.T, opts Options) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	opts.Release = Testing
+	l, err := NewLogger(&buf, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l, &buf
+}
+
+// TestInfowTextFormat tests that Infow renders a human-readable line
+// containing the message and fields.
+func TestInfowTextFormat(t *testing.T) {
+	l, buf := newBufLogger(t, Options{})
+	buf.Reset() // discard the startup message
+	l.Infow("hello world", "key", "value")
+	line := buf.String()
+	if !strings.Contains(line, "INFO") || !strings.Contains(line, "hello world") || !strings.Contains(line, "key=value") {
+		t.Fatalf("unexpected text output: %q", line)
+	}
+}
+
+// TestInfowJSONFormat tests that Infow renders a well-formed JSON object
+// with the expected keys when using JSONFormatter.
+func TestInfowJSONFormat(t *testing.T) {
+	l, buf := newBufLogger(t, Options{Formatter: JSONFormatter{}, BinaryName: "test", Version: "1.0"})
+	buf.Reset() // discard the startup message
+	l.Infow("hello world", "key", "value")
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("output was not valid JSON: %v: %q", err, buf.String())
+	}
+	for _, key := range []string{"ts", "level", "caller", "msg", "build", "key"} {
+		if _, ok := m[key]; !ok {
+			t.Errorf("expected key %q in JSON output, got %v", key, m)
+		}
+	}
+	if m["msg"] != "hello world" || m["level"] != "INFO" || m["key"] != "value" {
+		t.Errorf("unexpected JSON values: %v", m)
+	}
+}
+
+// TestWithFields tests that Logger.With attaches fields to every
+// subsequent structured log call without mutating the parent.
+func TestWithFields(t *testing.T) {
+	l, buf := newBufLogger(t, Options{Formatter: JSONFormatter{}})
+	buf.Reset()
+
+	child := l.With("request_id", "abc123")
+	child.Infow("handled request")
+	l.Infow("unrelated")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var childRecord, parentRecord map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &childRecord); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &parentRecord); err != nil {
+		t.Fatal(err)
+	}
+	if childRecord["request_id"] != "abc123" {
+		t.Errorf("expected child record to carry request_id, got %v", childRecord)
+	}
+	if _, ok := parentRecord["request_id"]; ok {
+		t.Errorf("parent logger should not have been mutated by With, got %v", parentRecord)
+	}
+}
+
+// TestLevelFiltering tests that records below Options.Level are dropped.
+func TestLevelFiltering(t *testing.T) {
+	l, buf := newBufLogger(t, Options{Formatter: JSONFormatter{}, Level: LevelWarn})
+	buf.Reset()
+
+	l.Debugw("should be dropped")
+	l.Infow("should also be dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output below the minimum level, got %q", buf.String())
+	}
+
+	l.Errorw("should be kept")
+	if !strings.Contains(buf.String(), "should be kept") {
+		t.Fatalf("expected the error record to be written, got %q", buf.String())
+	}
+}
+
+// TestConcurrentClassicAndStructuredWrites tests that mixing classic
+// methods (which write via the embedded *log.Logger's Output) with
+// structured methods (which write to staticW directly from logw) on the
+// same Logger, and on a With child sharing the same destination, does not
+// race. Run with -race to verify the synchronization; it's as much a
+// regression test for this test as for the code.
+func TestConcurrentClassicAndStructuredWrites(t *testing.T) {
+	l, _ := newBufLogger(t, Options{})
+	child := l.With("request_id", "abc123")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			l.Println("hello")
+		}()
+		go func() {
+			defer wg.Done()
+			l.Infow("hello", "k", "v")
+		}()
+		go func() {
+			defer wg.Done()
+			child.Infow("hello", "k", "v")
+		}()
+	}
+	wg.Wait()
+}
+
+// fakeLevelWriter is a levelWriter that records the Level it was called
+// with, so tests can tell WriteLevel apart from a plain Write.
+type fakeLevelWriter struct {
+	gotLevel    Level
+	gotWriteHit bool
+}
+
+func (w *fakeLevelWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (w *fakeLevelWriter) WriteLevel(level Level, p []byte) (int, error) {
+	w.gotLevel = level
+	w.gotWriteHit = true
+	return len(p), nil
+}
+
+// TestLogwPrefersWriteLevel tests that logw routes a structured record
+// through a sink's WriteLevel, rather than its plain Write, whenever the
+// sink implements levelWriter.
+func TestLogwPrefersWriteLevel(t *testing.T) {
+	w := &fakeLevelWriter{}
+	l, err := NewLogger(w, Options{Release: Testing, Formatter: TextFormatter{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.Criticalw("something bad")
+	if !w.gotWriteHit {
+		t.Fatal("expected logw to call WriteLevel")
+	}
+	if w.gotLevel != LevelCritical {
+		t.Errorf("expected WriteLevel to be called with LevelCritical, got %v", w.gotLevel)
+	}
+}
+
+// TestMultiLoggerFansOutWriteLevel tests that NewMultiLogger's multiWriter
+// propagates WriteLevel to sinks that support it (here, a syslogWriter
+// stand-in) while still reaching a plain io.Writer sink via Write — the
+// "file + stderr + syslog" fan-out the package's docs describe.
+func TestMultiLoggerFansOutWriteLevel(t *testing.T) {
+	levelAware := &fakeLevelWriter{}
+	var plain bytes.Buffer
+
+	l, err := NewMultiLogger(Options{Release: Testing, Formatter: TextFormatter{}}, levelAware, &plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.Criticalw("something bad")
+	if !levelAware.gotWriteHit || levelAware.gotLevel != LevelCritical {
+		t.Errorf("expected the level-aware sink to receive WriteLevel(LevelCritical, ...), got hit=%v level=%v", levelAware.gotWriteHit, levelAware.gotLevel)
+	}
+	if !strings.Contains(plain.String(), "something bad") {
+		t.Errorf("expected the plain sink to still receive the record via Write, got %q", plain.String())
+	}
+}
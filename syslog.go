@@ -0,0 +1,147 @@
+//go:build !windows
+
+package log
+
+import (
+	"log/syslog"
+	"strings"
+)
+
+// syslogWriter adapts a *syslog.Writer to the io.Writer interface expected
+// by NewLogger, mapping classic-method line prefixes (CRITICAL:, SEVERE:,
+// [ERROR], [DEBUG]) or, for structured records, the record's own Level
+// (see WriteLevel and levelWriter) to the matching syslog severity so that
+// e.g. `journalctl -p err` finds the right lines.
+type syslogWriter struct {
+	staticW *syslog.Writer
+}
+
+// syslogSeverity identifies which syslog.Writer method a line should be
+// sent through.
+type syslogSeverity int
+
+const (
+	syslogInfo syslogSeverity = iota
+	syslogDebug
+	syslogWarning
+	syslogErr
+	syslogAlert
+	syslogCrit
+)
+
+// Write sends b to syslog at the severity implied by its prefix, defaulting
+// to LOG_INFO for plain messages. Write is used for lines that reach the
+// sink without a known Level attached, i.e. the classic (non-structured)
+// Println/Errorf/Debugf/Severe/Critical methods, which funnel through the
+// embedded *log.Logger and arrive here as already-formatted text. For
+// structured records (Infow/Errorw/...), logw calls WriteLevel instead,
+// since those can carry a Formatter of the caller's choosing whose
+// rendering Write can't reliably parse a severity back out of.
+func (sw *syslogWriter) Write(b []byte) (int, error) {
+	return sw.send(severityFor(messagePayload(string(b))), string(b))
+}
+
+// WriteLevel sends b to syslog at the severity matching level directly,
+// bypassing Write's text-based prefix detection. It is used for structured
+// records, whose rendered form depends on the Logger's Formatter and may
+// not carry a detectable severity prefix at all (see levelWriter).
+func (sw *syslogWriter) WriteLevel(level Level, b []byte) (int, error) {
+	return sw.send(severityForLevel(level), string(b))
+}
+
+// send writes s to syslog at severity.
+func (sw *syslogWriter) send(severity syslogSeverity, s string) (int, error) {
+	var err error
+	switch severity {
+	case syslogCrit:
+		err = sw.staticW.Crit(s)
+	case syslogAlert:
+		err = sw.staticW.Alert(s)
+	case syslogErr:
+		err = sw.staticW.Err(s)
+	case syslogWarning:
+		err = sw.staticW.Warning(s)
+	case syslogDebug:
+		err = sw.staticW.Debug(s)
+	default:
+		err = sw.staticW.Info(s)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(s), nil
+}
+
+// severityFor maps the prefixes used by Logger's own methods (CRITICAL:,
+// SEVERE:, [ERROR], [DEBUG]) to a syslog severity, defaulting to
+// syslogInfo for plain messages. msg should already have the standard
+// log.Logger header stripped via messagePayload, since the prefix never
+// appears at the start of a formatted line.
+func severityFor(msg string) syslogSeverity {
+	switch {
+	case strings.HasPrefix(msg, "CRITICAL:"):
+		return syslogCrit
+	case strings.HasPrefix(msg, "SEVERE:"):
+		return syslogAlert
+	case strings.HasPrefix(msg, "[ERROR]"):
+		return syslogErr
+	case strings.HasPrefix(msg, "[DEBUG]"):
+		return syslogDebug
+	default:
+		return syslogInfo
+	}
+}
+
+// severityForLevel maps a Level directly to a syslog severity. Unlike
+// severityFor, it needs no text parsing and so is accurate regardless of
+// which Formatter rendered the record.
+func severityForLevel(level Level) syslogSeverity {
+	switch {
+	case level >= LevelCritical:
+		return syslogCrit
+	case level >= LevelSevere:
+		return syslogAlert
+	case level >= LevelError:
+		return syslogErr
+	case level >= LevelWarn:
+		return syslogWarning
+	case level <= LevelDebug:
+		return syslogDebug
+	default:
+		return syslogInfo
+	}
+}
+
+// messagePayload strips the standard log.Logger header (date, time, and
+// file:line, as written by every Logger constructed with NewLogger) from
+// s, returning the message text that follows. b reaches Write already
+// formatted with that header, so matching a severity prefix like
+// "CRITICAL:" against the raw line never succeeds; it only appears after
+// the header. If s doesn't have the expected four space-separated
+// segments (date, time, file:line:, message), s is returned unchanged.
+func messagePayload(s string) string {
+	parts := strings.SplitN(s, " ", 4)
+	if len(parts) == 4 {
+		return parts[3]
+	}
+	return s
+}
+
+// Close closes the underlying syslog connection.
+func (sw *syslogWriter) Close() error {
+	return sw.staticW.Close()
+}
+
+// NewSyslogLogger returns a logger that writes to the local syslog daemon,
+// tagged with tag. Debugln, Errorf/Errorln, Severe, and Critical messages are
+// mapped to LOG_DEBUG, LOG_ERR, LOG_ALERT, and LOG_CRIT respectively, by
+// their [DEBUG], [ERROR], SEVERE:, and CRITICAL: prefixes; everything else,
+// including Debug and Debugf (which write no prefix), is logged at LOG_INFO.
+// Structured records are mapped by their Level (see WriteLevel).
+func NewSyslogLogger(tag string, opts Options) (*Logger, error) {
+	w, err := syslog.New(syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return NewLogger(&syslogWriter{staticW: w}, opts)
+}
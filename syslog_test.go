@@ -0,0 +1,110 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+// formatLikeLogger renders msg exactly as Logger's embedded *log.Logger
+// would, so tests exercise the same header shape syslogWriter.Write
+// actually receives from NewLogger.
+func formatLikeLogger(t *testing.T, msg string) string {
+	l, buf := newBufLogger(t, Options{})
+	buf.Reset()
+	_ = l.Output(2, msg)
+	return buf.String()
+}
+
+// TestSeverityForRealisticLine tests that severityFor recovers the
+// intended syslog severity from a fully-formatted log line, i.e. one
+// carrying the date/time/file:line header that Write actually receives,
+// rather than from a bare prefix.
+func TestSeverityForRealisticLine(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   syslogSeverity
+	}{
+		{"CRITICAL: a critical message\n", syslogCrit},
+		{"SEVERE: a severe message\n", syslogAlert},
+		{"[ERROR] boom\n", syslogErr},
+		{"[DEBUG] verbose detail\n", syslogDebug},
+		{"a plain message\n", syslogInfo},
+	}
+	for _, tt := range tests {
+		line := formatLikeLogger(t, tt.prefix)
+		if got := severityFor(messagePayload(line)); got != tt.want {
+			t.Errorf("severityFor(messagePayload(%q)) = %v, want %v", line, got, tt.want)
+		}
+	}
+}
+
+// TestSeverityForLoggerMethods tests that each of Logger's classic methods
+// produces a line severityFor maps to the matching syslog severity, the
+// way syslogWriter.Write sees it when plugged into a real Logger.
+func TestSeverityForLoggerMethods(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := NewLogger(&buf, Options{Release: Testing})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		log  func()
+		want syslogSeverity
+	}{
+		{"Errorf", func() { l.Errorf("boom") }, syslogErr},
+		{"Debugln", func() { l.SetLevel(LevelDebug); l.Debugln("verbose detail") }, syslogDebug},
+		{"Println", func() { l.Println("a plain message") }, syslogInfo},
+	}
+	for _, tt := range tests {
+		buf.Reset()
+		tt.log()
+		if got := severityFor(messagePayload(buf.String())); got != tt.want {
+			t.Errorf("%s: severityFor(messagePayload(%q)) = %v, want %v", tt.name, buf.String(), got, tt.want)
+		}
+	}
+}
+
+// TestSeverityForLevel tests that severityForLevel maps every Level to its
+// expected syslog severity.
+func TestSeverityForLevel(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  syslogSeverity
+	}{
+		{LevelDebug, syslogDebug},
+		{LevelInfo, syslogInfo},
+		{LevelWarn, syslogWarning},
+		{LevelError, syslogErr},
+		{LevelSevere, syslogAlert},
+		{LevelCritical, syslogCrit},
+	}
+	for _, tt := range tests {
+		if got := severityForLevel(tt.level); got != tt.want {
+			t.Errorf("severityForLevel(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+// TestSyslogWriterWriteLevelIgnoresFormatter tests that severityForLevel, the
+// mapping WriteLevel uses, recovers the correct severity for a structured
+// record rendered by TextFormatter, while the text-based severityFor that
+// Write uses does not. TextFormatter places the level as its own token
+// rather than as one of the
+// CRITICAL:/SEVERE:/[ERROR]/[DEBUG] prefixes Write looks for. This is the
+// shape Infow/Errorw/Criticalw actually produce, as opposed to the classic
+// Println/Errorf/Debugf methods Write is meant for.
+func TestSyslogWriterWriteLevelIgnoresFormatter(t *testing.T) {
+	l, buf := newBufLogger(t, Options{Formatter: TextFormatter{}})
+	buf.Reset()
+	l.Criticalw("something bad", "k", "v")
+
+	line := buf.String()
+	if severityFor(messagePayload(line)) != syslogInfo {
+		t.Fatalf("expected the text-based matcher to be fooled by a TextFormatter line (got anything but syslogInfo), line was %q", line)
+	}
+	if got := severityForLevel(LevelCritical); got != syslogCrit {
+		t.Fatalf("expected severityForLevel(LevelCritical) = syslogCrit, got %v", got)
+	}
+}
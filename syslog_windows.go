@@ -0,0 +1,15 @@
+//go:build windows
+
+package log
+
+import "errors"
+
+// errSyslogUnsupported is returned by NewSyslogLogger on platforms without a
+// syslog daemon to connect to.
+var errSyslogUnsupported = errors.New("syslog is not supported on windows")
+
+// NewSyslogLogger is unavailable on windows, which has no syslog facility.
+// It always returns errSyslogUnsupported.
+func NewSyslogLogger(tag string, opts Options) (*Logger, error) {
+	return nil, errSyslogUnsupported
+}